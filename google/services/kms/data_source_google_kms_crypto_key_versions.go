@@ -0,0 +1,275 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+package kms
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-google/google/tpgresource"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+func DataSourceGoogleKmsCryptoKeyVersions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGoogleKmsCryptoKeyVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"crypto_key": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: `The crypto key that the returned versions belong to.
+Format: ''projects/{{project}}/locations/{{location}}/keyRings/{{keyRing}}/cryptoKeys/{{cryptoKey}}''.`,
+			},
+			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: `The filter argument is used to add a filter query parameter that limits which crypto key versions are returned by the API.
+See the [upstream API documentation](https://cloud.google.com/kms/docs/reference/rest/v1/projects.locations.keyRings.cryptoKeys.cryptoKeyVersions/list) for the format of the filter expression.`,
+			},
+			"order_by": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The order_by argument is used to add an orderBy query parameter that specifies the order of the results, e.g. ''createTime desc''.`,
+			},
+			"view": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `The view argument controls which fields of each CryptoKeyVersion are populated. Defaults to the API's own default view. Use 'FULL' to include the attestation field.`,
+			},
+			"use_grpc": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: `Route ListCryptoKeyVersions through the gRPC transport instead of the default REST transport. Cloud KMS is rate-limited more aggressively over REST, so high-QPS callers may prefer gRPC.`,
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: `A list of crypto key versions for the given crypto key.`,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `The resource name of the CryptoKeyVersion.`,
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `The current state of the CryptoKeyVersion.`,
+						},
+						"algorithm": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `The CryptoKeyVersionAlgorithm that this CryptoKeyVersion supports.`,
+						},
+						"protection_level": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `The ProtectionLevel describing how crypto operations are performed with this CryptoKeyVersion.`,
+						},
+						"generate_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `The time this CryptoKeyVersion's key material was generated.`,
+						},
+						"destroy_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `The time this CryptoKeyVersion's key material is scheduled for destruction, if any.`,
+						},
+						"attestation": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: `Statement that was generated and signed by the HSM at key creation time, only populated for HSM protection level keys when 'view' is set to 'FULL'.`,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"format": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: `The format of the attestation data.`,
+									},
+									"content": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: `The base64 encoded attestation data.`,
+									},
+								},
+							},
+						},
+						"external_protection_level_options": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: `Options for this CryptoKeyVersion if it is an EXTERNAL or EXTERNAL_VPC key.`,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"external_key_uri": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: `The URI for an external resource that this CryptoKeyVersion represents.`,
+									},
+									"ekm_connection_key_path": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: `The path to the external key material on the EKM when using EkmConnection e.g., "v0/my/key/path".`,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGoogleKmsCryptoKeyVersionsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*transport_tpg.Config)
+
+	cryptoKey := d.Get("crypto_key").(string)
+	id := fmt.Sprintf("%s/cryptoKeyVersions", cryptoKey)
+	d.SetId(id)
+
+	var versions []interface{}
+	var err error
+	if d.Get("use_grpc").(bool) {
+		versions, err = dataSourceKMSCryptoKeyVersionsListGRPC(d, meta)
+	} else {
+		versions, err = dataSourceKMSCryptoKeyVersionsList(d, meta)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("versions", flattenKMSCryptoKeyVersionsList(d, config, versions)); err != nil {
+		return fmt.Errorf("error setting versions: %s", err)
+	}
+
+	if err := tpgresource.SetDataSourceLabels(d); err != nil {
+		return err
+	}
+
+	if d.Id() == "" {
+		return fmt.Errorf("%s not found", id)
+	}
+	return nil
+}
+
+// dataSourceKMSCryptoKeyVersionsList pages through ListCryptoKeyVersions,
+// accumulating every version across pages before returning.
+func dataSourceKMSCryptoKeyVersionsList(d *schema.ResourceData, meta interface{}) ([]interface{}, error) {
+	config := meta.(*transport_tpg.Config)
+	userAgent, err := tpgresource.GenerateUserAgentString(d, config.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := tpgresource.ReplaceVars(d, config, "{{KMSBasePath}}{{crypto_key}}/cryptoKeyVersions")
+	if err != nil {
+		return nil, err
+	}
+
+	billingProject := ""
+
+	if parts := regexp.MustCompile(`projects\/([^\/]+)\/`).FindStringSubmatch(url); parts != nil {
+		billingProject = parts[1]
+	}
+
+	// err == nil indicates that the billing_project value was found
+	if bp, err := tpgresource.GetBillingProject(d, config); err == nil {
+		billingProject = bp
+	}
+
+	queryParams := map[string]string{}
+	if v, ok := d.GetOk("filter"); ok {
+		queryParams["filter"] = v.(string)
+	}
+	if v, ok := d.GetOk("order_by"); ok {
+		queryParams["orderBy"] = v.(string)
+	}
+	if v, ok := d.GetOk("view"); ok {
+		queryParams["view"] = v.(string)
+	}
+
+	if len(queryParams) > 0 {
+		url, err = transport_tpg.AddQueryParams(url, queryParams)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	it := &transport_tpg.PageIterator{
+		Config:                      config,
+		Method:                      "GET",
+		Project:                     billingProject,
+		RawURL:                      url,
+		UserAgent:                   userAgent,
+		ItemsField:                  "cryptoKeyVersions",
+		ErrorRetryBackoffPredicates: kmsRetryableErrorPredicates,
+		BackoffPolicy:               kmsBackoffPolicy,
+	}
+
+	allVersions := make([]interface{}, 0)
+	if err := it.ForEachPage(0, func(page []interface{}) error {
+		allVersions = append(allVersions, page...)
+		return nil
+	}); err != nil {
+		return nil, transport_tpg.HandleNotFoundError(err, d, fmt.Sprintf("KMSCryptoKeyVersions %q", d.Id()))
+	}
+
+	return allVersions, nil
+}
+
+// flattenKMSCryptoKeyVersionsList flattens a list of crypto key versions from a given crypto key
+func flattenKMSCryptoKeyVersionsList(d *schema.ResourceData, config *transport_tpg.Config, versionsList []interface{}) []interface{} {
+	var versions []interface{}
+	for _, v := range versionsList {
+		version := v.(map[string]interface{})
+
+		data := map[string]interface{}{}
+		data["name"] = version["name"]
+		data["state"] = version["state"]
+		data["algorithm"] = version["algorithm"]
+		data["protection_level"] = version["protectionLevel"]
+		data["generate_time"] = version["generateTime"]
+		data["destroy_time"] = version["destroyTime"]
+		data["attestation"] = flattenKMSCryptoKeyVersionAttestation(version["attestation"], d, config)
+		data["external_protection_level_options"] = flattenKMSCryptoKeyVersionExternalProtectionLevelOptions(version["externalProtectionLevelOptions"], d, config)
+		versions = append(versions, data)
+	}
+
+	return versions
+}
+
+func flattenKMSCryptoKeyVersionAttestation(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) []interface{} {
+	if v == nil {
+		return nil
+	}
+	original := v.(map[string]interface{})
+	if len(original) == 0 {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"format":  original["format"],
+			"content": original["content"],
+		},
+	}
+}
+
+func flattenKMSCryptoKeyVersionExternalProtectionLevelOptions(v interface{}, d *schema.ResourceData, config *transport_tpg.Config) []interface{} {
+	if v == nil {
+		return nil
+	}
+	original := v.(map[string]interface{})
+	if len(original) == 0 {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"external_key_uri":        original["externalKeyUri"],
+			"ekm_connection_key_path": original["ekmConnectionKeyPath"],
+		},
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+package kms
+
+import (
+	"context"
+
+	"cloud.google.com/go/kms/apiv1/kmspb"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+// kmsGRPCEndpoint is the Cloud KMS gRPC endpoint the google-cloud-go KMS
+// client dials by default.
+const kmsGRPCEndpoint = "cloudkms.googleapis.com:443"
+
+const kmsListCryptoKeyVersionsMethod = "/google.cloud.kms.v1.KeyManagementService/ListCryptoKeyVersions"
+
+// kmsGRPCTransport is package-level so every "use_grpc" caller shares the
+// same pooled *grpc.ClientConn to cloudkms.googleapis.com instead of
+// dialing a fresh connection per read.
+var kmsGRPCTransport = &transport_tpg.GRPCTransport{}
+
+// buildListCryptoKeyVersionsRequest translates the data source's schema
+// fields into the gRPC ListCryptoKeyVersionsRequest, independently of the
+// network call so it can be unit tested.
+func buildListCryptoKeyVersionsRequest(d *schema.ResourceData) *kmspb.ListCryptoKeyVersionsRequest {
+	req := &kmspb.ListCryptoKeyVersionsRequest{
+		Parent: d.Get("crypto_key").(string),
+	}
+	if v, ok := d.GetOk("filter"); ok {
+		req.Filter = v.(string)
+	}
+	if v, ok := d.GetOk("order_by"); ok {
+		req.OrderBy = v.(string)
+	}
+	if v, ok := d.GetOk("view"); ok {
+		if view, ok := kmspb.CryptoKeyVersion_CryptoKeyVersionView_value[v.(string)]; ok {
+			req.View = kmspb.CryptoKeyVersion_CryptoKeyVersionView(view)
+		}
+	}
+	return req
+}
+
+// dataSourceKMSCryptoKeyVersionsListGRPC pages through ListCryptoKeyVersions
+// over gRPC, the transport the google-cloud-go KMS client itself uses,
+// accumulating every version across pages before returning.
+func dataSourceKMSCryptoKeyVersionsListGRPC(d *schema.ResourceData, meta interface{}) ([]interface{}, error) {
+	ctx := context.Background()
+	req := buildListCryptoKeyVersionsRequest(d)
+
+	allVersions := make([]interface{}, 0)
+	for {
+		reply := &kmspb.ListCryptoKeyVersionsResponse{}
+		res, err := kmsGRPCTransport.Invoke(ctx, kmsGRPCEndpoint, kmsListCryptoKeyVersionsMethod, req, reply)
+		if err != nil {
+			return nil, err
+		}
+
+		if versions, ok := res["cryptoKeyVersions"].([]interface{}); ok {
+			allVersions = append(allVersions, versions...)
+		}
+
+		token, ok := res["nextPageToken"].(string)
+		if !ok || token == "" {
+			break
+		}
+		req.PageToken = token
+	}
+
+	return allVersions, nil
+}
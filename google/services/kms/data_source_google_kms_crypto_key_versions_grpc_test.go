@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+package kms
+
+import (
+	"testing"
+
+	"cloud.google.com/go/kms/apiv1/kmspb"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestBuildListCryptoKeyVersionsRequest(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  map[string]interface{}
+		want *kmspb.ListCryptoKeyVersionsRequest
+	}{
+		{
+			name: "minimal",
+			raw: map[string]interface{}{
+				"crypto_key": "projects/p/locations/l/keyRings/r/cryptoKeys/k",
+			},
+			want: &kmspb.ListCryptoKeyVersionsRequest{
+				Parent: "projects/p/locations/l/keyRings/r/cryptoKeys/k",
+			},
+		},
+		{
+			name: "filter, order_by and view",
+			raw: map[string]interface{}{
+				"crypto_key": "projects/p/locations/l/keyRings/r/cryptoKeys/k",
+				"filter":     "state=ENABLED",
+				"order_by":   "createTime desc",
+				"view":       "FULL",
+			},
+			want: &kmspb.ListCryptoKeyVersionsRequest{
+				Parent:  "projects/p/locations/l/keyRings/r/cryptoKeys/k",
+				Filter:  "state=ENABLED",
+				OrderBy: "createTime desc",
+				View:    kmspb.CryptoKeyVersion_FULL,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, DataSourceGoogleKmsCryptoKeyVersions().Schema, c.raw)
+			got := buildListCryptoKeyVersionsRequest(d)
+
+			if got.Parent != c.want.Parent {
+				t.Errorf("Parent = %q, want %q", got.Parent, c.want.Parent)
+			}
+			if got.Filter != c.want.Filter {
+				t.Errorf("Filter = %q, want %q", got.Filter, c.want.Filter)
+			}
+			if got.OrderBy != c.want.OrderBy {
+				t.Errorf("OrderBy = %q, want %q", got.OrderBy, c.want.OrderBy)
+			}
+			if got.View != c.want.View {
+				t.Errorf("View = %v, want %v", got.View, c.want.View)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+package kms_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-google/google/acctest"
+)
+
+func TestAccDataSourceGoogleKmsCryptoKeyVersions_basic(t *testing.T) {
+	kms := acctest.BootstrapKMSKey(t)
+
+	acctest.VcrTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccTestPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGoogleKmsCryptoKeyVersions_basic(kms.CryptoKey.Name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.google_kms_crypto_key_versions.all_versions", "id", regexp.MustCompile(kms.CryptoKey.Name)),
+					resource.TestCheckResourceAttr("data.google_kms_crypto_key_versions.all_versions", "crypto_key", kms.CryptoKey.Name),
+					resource.TestMatchResourceAttr("data.google_kms_crypto_key_versions.all_versions", "versions.#", regexp.MustCompile("[1-9]+[0-9]*")),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGoogleKmsCryptoKeyVersions_basic(cryptoKeyName string) string {
+	return fmt.Sprintf(`
+data "google_kms_crypto_key_versions" "all_versions" {
+  crypto_key = "%s"
+}
+`, cryptoKeyName)
+}
+
+func TestAccDataSourceGoogleKmsCryptoKeyVersions_full(t *testing.T) {
+	kms := acctest.BootstrapKMSKey(t)
+
+	acctest.VcrTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccTestPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGoogleKmsCryptoKeyVersions_full(kms.CryptoKey.Name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.google_kms_crypto_key_versions.full_view", "versions.#", regexp.MustCompile("[1-9]+[0-9]*")),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGoogleKmsCryptoKeyVersions_full(cryptoKeyName string) string {
+	return fmt.Sprintf(`
+data "google_kms_crypto_key_versions" "full_view" {
+  crypto_key = "%s"
+  view       = "FULL"
+  order_by   = "createTime desc"
+}
+`, cryptoKeyName)
+}
+
+func TestAccDataSourceGoogleKmsCryptoKeyVersions_useGrpc(t *testing.T) {
+	kms := acctest.BootstrapKMSKey(t)
+
+	acctest.VcrTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccTestPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGoogleKmsCryptoKeyVersions_useGrpc(kms.CryptoKey.Name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.google_kms_crypto_key_versions.via_grpc", "use_grpc", "true"),
+					resource.TestMatchResourceAttr("data.google_kms_crypto_key_versions.via_grpc", "versions.#", regexp.MustCompile("[1-9]+[0-9]*")),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGoogleKmsCryptoKeyVersions_useGrpc(cryptoKeyName string) string {
+	return fmt.Sprintf(`
+data "google_kms_crypto_key_versions" "via_grpc" {
+  crypto_key = "%s"
+  use_grpc   = true
+}
+`, cryptoKeyName)
+}
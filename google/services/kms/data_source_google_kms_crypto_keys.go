@@ -4,8 +4,6 @@ package kms
 
 import (
 	"fmt"
-	"log"
-	"net/http"
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -28,9 +26,20 @@ func DataSourceGoogleKmsCryptoKeys() *schema.Resource {
 Format: ''projects/{{project}}/locations/{{location}}/keyRings/{{keyRing}}''.`,
 			},
 			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: `The filter argument is used to add a filter query parameter that limits which crypto keys are returned by the API.
+See the [upstream API documentation](https://cloud.google.com/kms/docs/reference/rest/v1/projects.locations.keyRings.cryptoKeys/list) for the format of the filter expression, e.g. ''name:foo AND purpose=ENCRYPT_DECRYPT''.`,
+			},
+			"page_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: `The number of CryptoKeys to read per page when listing. Defaults to the API's own default when unset.`,
+			},
+			"version_view": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "",
+				Description: `The fields of the primary version to include in the response. Defaults to 'CRYPTO_KEY_VERSION_VIEW_UNSPECIFIED', which is reduced metadata about the primary version. Use 'FULL' to request every field of the primary version, including its attestation.`,
 			},
 			"keys": {
 				Type:        schema.TypeList,
@@ -99,26 +108,46 @@ func dataSourceKMSCryptoKeysList(d *schema.ResourceData, meta interface{}) (map[
 		billingProject = bp
 	}
 
-	headers := make(http.Header)
-	res, err := transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
-		Config:    config,
-		Method:    "GET",
-		Project:   billingProject,
-		RawURL:    url,
-		UserAgent: userAgent,
-		Headers:   headers,
-	})
-	if err != nil {
-		return nil, transport_tpg.HandleNotFoundError(err, d, fmt.Sprintf("KMSCryptoKeys %q", d.Id()))
+	queryParams := map[string]string{}
+	if v, ok := d.GetOk("filter"); ok {
+		queryParams["filter"] = v.(string)
+	}
+	pageSize := 0
+	if v, ok := d.GetOk("page_size"); ok {
+		pageSize = v.(int)
+		queryParams["pageSize"] = fmt.Sprintf("%d", pageSize)
+	}
+	if v, ok := d.GetOk("version_view"); ok {
+		queryParams["versionView"] = v.(string)
+	}
+
+	if len(queryParams) > 0 {
+		url, err = transport_tpg.AddQueryParams(url, queryParams)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if res == nil {
-		// Decoding the object has resulted in it being gone. It may be marked deleted
-		log.Printf("[DEBUG] Removing KMSCryptoKey because it no longer exists.")
-		d.SetId("")
-		return nil, nil
+	it := &transport_tpg.PageIterator{
+		Config:                      config,
+		Method:                      "GET",
+		Project:                     billingProject,
+		RawURL:                      url,
+		UserAgent:                   userAgent,
+		ItemsField:                  "cryptoKeys",
+		ErrorRetryBackoffPredicates: kmsRetryableErrorPredicates,
+		BackoffPolicy:               kmsBackoffPolicy,
 	}
-	return res, nil
+
+	allKeys := make([]interface{}, 0)
+	if err := it.ForEachPage(pageSize, func(page []interface{}) error {
+		allKeys = append(allKeys, page...)
+		return nil
+	}); err != nil {
+		return nil, transport_tpg.HandleNotFoundError(err, d, fmt.Sprintf("KMSCryptoKeys %q", d.Id()))
+	}
+
+	return map[string]interface{}{"cryptoKeys": allKeys}, nil
 }
 
 // flattenKMSKeysList flattens a list of crypto keys from a given crypto key ring
@@ -5,9 +5,11 @@ package kms_test
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-provider-google/google/acctest"
 )
 
@@ -37,3 +39,150 @@ data "google_kms_crypto_keys" "all_keys_in_ring" {
 }
 `, keyRingName)
 }
+
+// TestAccDataSourceGoogleKmsCryptoKeys_paginated proves accumulation across
+// pages by forcing page_size down to 1 against the shared bootstrap key
+// ring (which, across the whole acceptance suite, holds more than one
+// crypto key) and asserting the paginated result has exactly as many keys
+// as an unpaginated read of the same ring. If the pagination loop stopped
+// after the first page, this count would come up short.
+func TestAccDataSourceGoogleKmsCryptoKeys_paginated(t *testing.T) {
+	kms := acctest.BootstrapKMSKey(t)
+
+	acctest.VcrTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccTestPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGoogleKmsCryptoKeys_paginated(kms.KeyRing.Name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.google_kms_crypto_keys.paginated", "keys.#", regexp.MustCompile("[1-9]+[0-9]*")),
+					testAccCheckKmsCryptoKeysCountsMatch("data.google_kms_crypto_keys.all_keys_in_ring", "data.google_kms_crypto_keys.paginated"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGoogleKmsCryptoKeys_paginated(keyRingName string) string {
+	return fmt.Sprintf(`
+data "google_kms_crypto_keys" "all_keys_in_ring" {
+  key_ring = "%[1]s"
+}
+
+data "google_kms_crypto_keys" "paginated" {
+  key_ring  = "%[1]s"
+  page_size = 1
+}
+`, keyRingName)
+}
+
+// TestAccDataSourceGoogleKmsCryptoKeys_filtered proves the filter attribute
+// is actually forwarded to the API, rather than merely returning a
+// non-empty list regardless of its value: the filtered read must return no
+// more keys than the unfiltered read, and every key it does return must
+// match the filter's purpose clause.
+func TestAccDataSourceGoogleKmsCryptoKeys_filtered(t *testing.T) {
+	kms := acctest.BootstrapKMSKey(t)
+
+	acctest.VcrTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccTestPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGoogleKmsCryptoKeys_filtered(kms.KeyRing.Name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.google_kms_crypto_keys.filtered", "keys.#"),
+					testAccCheckKmsCryptoKeysFilterNarrowsResults("data.google_kms_crypto_keys.all_keys_in_ring", "data.google_kms_crypto_keys.filtered"),
+					testAccCheckKmsCryptoKeysAllHavePurpose("data.google_kms_crypto_keys.filtered", "ENCRYPT_DECRYPT"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGoogleKmsCryptoKeys_filtered(keyRingName string) string {
+	return fmt.Sprintf(`
+data "google_kms_crypto_keys" "all_keys_in_ring" {
+  key_ring = "%[1]s"
+}
+
+data "google_kms_crypto_keys" "filtered" {
+  key_ring = "%[1]s"
+  filter   = "purpose=ENCRYPT_DECRYPT"
+}
+`, keyRingName)
+}
+
+// testAccCheckKmsCryptoKeysCountsMatch asserts two google_kms_crypto_keys
+// data sources returned the same number of keys.
+func testAccCheckKmsCryptoKeysCountsMatch(wantAddr, gotAddr string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		want, err := kmsCryptoKeysCount(s, wantAddr)
+		if err != nil {
+			return err
+		}
+		got, err := kmsCryptoKeysCount(s, gotAddr)
+		if err != nil {
+			return err
+		}
+		if got != want {
+			return fmt.Errorf("%s returned %d keys, but %s returned %d keys; pagination should accumulate every page", gotAddr, got, wantAddr, want)
+		}
+		return nil
+	}
+}
+
+// testAccCheckKmsCryptoKeysFilterNarrowsResults asserts the filtered data
+// source never returns more keys than the unfiltered one.
+func testAccCheckKmsCryptoKeysFilterNarrowsResults(allAddr, filteredAddr string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		all, err := kmsCryptoKeysCount(s, allAddr)
+		if err != nil {
+			return err
+		}
+		filtered, err := kmsCryptoKeysCount(s, filteredAddr)
+		if err != nil {
+			return err
+		}
+		if filtered > all {
+			return fmt.Errorf("%s returned %d keys, more than the %d returned by %s; filter was not forwarded to the API", filteredAddr, filtered, all, allAddr)
+		}
+		return nil
+	}
+}
+
+// testAccCheckKmsCryptoKeysAllHavePurpose asserts every key returned by addr
+// has the given purpose, proving the filter expression was honored rather
+// than ignored.
+func testAccCheckKmsCryptoKeysAllHavePurpose(addr, purpose string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[addr]
+		if !ok {
+			return fmt.Errorf("data source %s not found in state", addr)
+		}
+		count, err := kmsCryptoKeysCount(s, addr)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < count; i++ {
+			key := fmt.Sprintf("keys.%d.purpose", i)
+			if got := rs.Primary.Attributes[key]; got != purpose {
+				return fmt.Errorf("%s.%s = %q, want %q", addr, key, got, purpose)
+			}
+		}
+		return nil
+	}
+}
+
+func kmsCryptoKeysCount(s *terraform.State, addr string) (int, error) {
+	rs, ok := s.RootModule().Resources[addr]
+	if !ok {
+		return 0, fmt.Errorf("data source %s not found in state", addr)
+	}
+	count, err := strconv.Atoi(rs.Primary.Attributes["keys.#"])
+	if err != nil {
+		return 0, fmt.Errorf("%s.keys.# is not a number: %w", addr, err)
+	}
+	return count, nil
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+package kms
+
+import (
+	"time"
+
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+// kmsBackoffPolicy overrides transport_tpg.DefaultBackoffPolicy for KMS list
+// calls, which are rate-limited more aggressively than most Google APIs.
+var kmsBackoffPolicy = &transport_tpg.BackoffPolicy{
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+	Multiplier:  2.0,
+	MaxAttempts: 10,
+}
+
+// kmsRetryableErrorPredicates retries KMS list calls on 429 (quota
+// exceeded) and 503 (backend unavailable), the two errors Cloud KMS
+// documents as transient.
+var kmsRetryableErrorPredicates = []transport_tpg.RetryErrorPredicateFunc{
+	func(err error) (bool, string) {
+		if transport_tpg.IsGoogleApiErrorWithCode(err, 429) {
+			return true, "KMS quota exceeded, retrying with backoff"
+		}
+		if transport_tpg.IsGoogleApiErrorWithCode(err, 503) {
+			return true, "KMS backend unavailable, retrying with backoff"
+		}
+		return false, ""
+	},
+}
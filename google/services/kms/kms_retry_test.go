@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+package kms
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+)
+
+func TestKmsRetryableErrorPredicates(t *testing.T) {
+	cases := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"quota exceeded", &googleapi.Error{Code: 429}, true},
+		{"backend unavailable", &googleapi.Error{Code: 503}, true},
+		{"not found", &googleapi.Error{Code: 404}, false},
+		{"non-api error", errors.New("boom"), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			isRetryable, _ := kmsRetryableErrorPredicates[0](c.err)
+			if isRetryable != c.retryable {
+				t.Errorf("got retryable=%v, want %v", isRetryable, c.retryable)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"google.golang.org/api/option"
+	gtransport "google.golang.org/api/transport/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Transport abstracts how a request is executed against a Google API.
+// RESTTransport, which preserves today's net/http + encoding/json behavior,
+// is its only implementation here. GRPCTransport, for high-QPS services
+// (Cloud KMS first) whose google-cloud-go clients talk gRPC with gax
+// retryers, does not implement Transport; it is called through Invoke.
+type Transport interface {
+	Do(ctx context.Context, opt SendRequestOptions) (map[string]interface{}, error)
+}
+
+// RESTTransport is the default Transport, delegating to SendRequest.
+type RESTTransport struct{}
+
+func (t *RESTTransport) Do(ctx context.Context, opt SendRequestOptions) (map[string]interface{}, error) {
+	return SendRequest(opt)
+}
+
+// DefaultGRPCRetryableCodes mirrors the codes gax-go retries by default for
+// the google-cloud-go KMS client.
+var DefaultGRPCRetryableCodes = []codes.Code{
+	codes.Unavailable,
+	codes.DeadlineExceeded,
+	codes.ResourceExhausted,
+}
+
+// GRPCTransport dials Google APIs over gRPC instead of REST, pooling one
+// *grpc.ClientConn per endpoint and dialing lazily on first use. Unlike
+// SendRequest/RESTTransport, callers drive it directly with the
+// request/response proto.Message pair for the RPC they want to invoke,
+// since gRPC doesn't have a REST URL/JSON-body shape to generalize over.
+type GRPCTransport struct {
+	// RetryableCodes lists the gRPC status codes ErrorRetryPredicates should
+	// treat as transient. Defaults to DefaultGRPCRetryableCodes when nil.
+	RetryableCodes []codes.Code
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// conn returns a pooled *grpc.ClientConn for endpoint (e.g.
+// "cloudkms.googleapis.com:443"), dialing it lazily the first time it's
+// requested. Credentials/ADC/quota-project/universe-domain handling is
+// delegated to google.golang.org/api/transport/grpc, the same dialer the
+// google-cloud-go clients use, rather than hand-rolling TLS and oauth.
+func (t *GRPCTransport) conn(ctx context.Context, endpoint string, opts ...option.ClientOption) (*grpc.ClientConn, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.conns == nil {
+		t.conns = make(map[string]*grpc.ClientConn)
+	}
+	if c, ok := t.conns[endpoint]; ok {
+		return c, nil
+	}
+
+	dialOpts := append([]option.ClientOption{option.WithEndpoint(endpoint)}, opts...)
+	conn, err := gtransport.Dial(ctx, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing %s: %w", endpoint, err)
+	}
+	t.conns[endpoint] = conn
+	return conn, nil
+}
+
+// Invoke issues a unary gRPC call for method against endpoint and decodes
+// the response proto into a map[string]interface{} compatible with the
+// flatten helpers REST responses already use.
+func (t *GRPCTransport) Invoke(ctx context.Context, endpoint, method string, req, reply proto.Message, opts ...option.ClientOption) (map[string]interface{}, error) {
+	conn, err := t.conn(ctx, endpoint, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.Invoke(ctx, method, req, reply); err != nil {
+		return nil, t.wrapStatusError(err)
+	}
+
+	b, err := protojson.Marshal(reply)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling %s response: %w", method, err)
+	}
+
+	result := make(map[string]interface{})
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, fmt.Errorf("error decoding %s response: %w", method, err)
+	}
+	return result, nil
+}
+
+// wrapStatusError annotates a gRPC error with whether it's one of the codes
+// this transport considers retryable, so it composes with the existing
+// RetryErrorPredicateFunc machinery in transport.go.
+func (t *GRPCTransport) wrapStatusError(err error) error {
+	if IsRetryableGRPCCode(err, t.retryableCodes()) {
+		return fmt.Errorf("retryable gRPC error (%s): %w", status.Code(err), err)
+	}
+	return err
+}
+
+func (t *GRPCTransport) retryableCodes() []codes.Code {
+	if t.RetryableCodes != nil {
+		return t.RetryableCodes
+	}
+	return DefaultGRPCRetryableCodes
+}
+
+// IsRetryableGRPCCode reports whether err's gRPC status code is one of
+// retryable, for use as (or from) an ErrorRetryPredicateFunc.
+func IsRetryableGRPCCode(err error, retryable []codes.Code) bool {
+	code := status.Code(err)
+	for _, c := range retryable {
+		if code == c {
+			return true
+		}
+	}
+	return false
+}
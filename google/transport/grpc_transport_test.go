@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+package transport
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsRetryableGRPCCode(t *testing.T) {
+	retryable := []codes.Code{codes.Unavailable, codes.DeadlineExceeded}
+
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"unavailable is retryable", status.Error(codes.Unavailable, "down"), true},
+		{"deadline exceeded is retryable", status.Error(codes.DeadlineExceeded, "slow"), true},
+		{"not found is not retryable", status.Error(codes.NotFound, "gone"), false},
+		{"nil error is not retryable", nil, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := IsRetryableGRPCCode(c.err, retryable); got != c.want {
+				t.Errorf("IsRetryableGRPCCode(%v) = %v, want %v", c.err, got, c.want)
+			}
+		})
+	}
+}
+
+func TestDefaultGRPCRetryableCodes(t *testing.T) {
+	for _, want := range []codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted} {
+		found := false
+		for _, c := range DefaultGRPCRetryableCodes {
+			if c == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("DefaultGRPCRetryableCodes missing %s", want)
+		}
+	}
+}
+
+// fakeTransport lets callers of Transport be unit tested without a real
+// REST or gRPC round trip.
+type fakeTransport struct {
+	result map[string]interface{}
+	err    error
+}
+
+func (f *fakeTransport) Do(ctx context.Context, opt SendRequestOptions) (map[string]interface{}, error) {
+	return f.result, f.err
+}
+
+func TestFakeTransportSatisfiesTransport(t *testing.T) {
+	var tr Transport = &fakeTransport{result: map[string]interface{}{"ok": true}}
+	res, err := tr.Do(context.Background(), SendRequestOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res["ok"] != true {
+		t.Errorf("got %v, want ok=true", res)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+package transport
+
+import (
+	"net/http"
+
+	"google.golang.org/api/iterator"
+)
+
+// PageIterator walks a paginated Google API list endpoint one item at a
+// time, fetching pages lazily via SendRequest instead of materializing the
+// whole result set up front. It's modeled after google.golang.org/api/iterator.
+type PageIterator struct {
+	Config    *Config
+	Method    string
+	Project   string
+	RawURL    string
+	UserAgent string
+	Headers   http.Header
+	Body      map[string]interface{}
+
+	// ItemsField is the name of the response field holding the page of
+	// results, e.g. "cryptoKeys", "items", "resources".
+	ItemsField string
+
+	// ErrorRetryPredicates, ErrorAbortPredicates and ErrorRetryBackoffPredicates
+	// are forwarded to every per-page SendRequest call, as-is.
+	ErrorRetryPredicates        []RetryErrorPredicateFunc
+	ErrorAbortPredicates        []RetryErrorPredicateFunc
+	ErrorRetryBackoffPredicates []RetryErrorPredicateFunc
+
+	// BackoffPolicy overrides DefaultBackoffPolicy for ErrorRetryBackoffPredicates
+	// retries on every per-page SendRequest call. Nil uses the default.
+	BackoffPolicy *BackoffPolicy
+
+	items     []interface{}
+	idx       int
+	pageToken string
+	started   bool
+}
+
+// sendRequestFunc is a package-level hook so tests can stub out the actual
+// network call and assert on PageIterator's pagination logic in isolation.
+var sendRequestFunc = SendRequest
+
+// Next returns the next item, fetching additional pages as needed. It
+// returns iterator.Done once every page has been consumed.
+func (p *PageIterator) Next() (interface{}, error) {
+	for p.idx >= len(p.items) {
+		if p.started && p.pageToken == "" {
+			return nil, iterator.Done
+		}
+		if err := p.fetchPage(); err != nil {
+			return nil, err
+		}
+	}
+	item := p.items[p.idx]
+	p.idx++
+	return item, nil
+}
+
+// ForEachPage drains the iterator, invoking f with up to pageSize items at
+// a time so callers can process results in batches that scale with
+// pageSize rather than the total result count.
+func (p *PageIterator) ForEachPage(pageSize int, f func([]interface{}) error) error {
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	batch := make([]interface{}, 0, pageSize)
+	for {
+		item, err := p.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		batch = append(batch, item)
+		if len(batch) == pageSize {
+			if err := f(batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		if err := f(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PageIterator) fetchPage() error {
+	rawURL := p.RawURL
+	if p.pageToken != "" {
+		var err error
+		rawURL, err = AddQueryParams(rawURL, map[string]string{"pageToken": p.pageToken})
+		if err != nil {
+			return err
+		}
+	}
+
+	method := p.Method
+	if method == "" {
+		method = "GET"
+	}
+
+	headers := p.Headers
+	if headers == nil {
+		headers = make(http.Header)
+	}
+
+	res, err := sendRequestFunc(SendRequestOptions{
+		Config:                      p.Config,
+		Method:                      method,
+		Project:                     p.Project,
+		RawURL:                      rawURL,
+		UserAgent:                   p.UserAgent,
+		Headers:                     headers,
+		Body:                        p.Body,
+		ErrorRetryPredicates:        p.ErrorRetryPredicates,
+		ErrorAbortPredicates:        p.ErrorAbortPredicates,
+		ErrorRetryBackoffPredicates: p.ErrorRetryBackoffPredicates,
+		BackoffPolicy:               p.BackoffPolicy,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.started = true
+	p.idx = 0
+	p.items = nil
+	p.pageToken = ""
+
+	if res == nil {
+		return nil
+	}
+	if items, ok := res[p.ItemsField].([]interface{}); ok {
+		p.items = items
+	}
+	if token, ok := res["nextPageToken"].(string); ok {
+		p.pageToken = token
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+package transport
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/iterator"
+)
+
+// stubPages returns a sendRequestFunc-compatible function that serves pages
+// from responses in order, following pageToken the way a real API would.
+func stubPages(t *testing.T, pages [][]interface{}) func(SendRequestOptions) (map[string]interface{}, error) {
+	t.Helper()
+	call := 0
+	return func(opt SendRequestOptions) (map[string]interface{}, error) {
+		if call >= len(pages) {
+			t.Fatalf("unexpected extra page request (call %d)", call)
+		}
+		res := map[string]interface{}{"items": pages[call]}
+		call++
+		if call < len(pages) {
+			res["nextPageToken"] = "token"
+		}
+		return res, nil
+	}
+}
+
+func withStubbedSendRequest(t *testing.T, pages [][]interface{}) {
+	t.Helper()
+	old := sendRequestFunc
+	sendRequestFunc = stubPages(t, pages)
+	t.Cleanup(func() { sendRequestFunc = old })
+}
+
+func TestPageIteratorForEachPage_multiPageAccumulation(t *testing.T) {
+	withStubbedSendRequest(t, [][]interface{}{
+		{"a", "b"},
+		{"c", "d"},
+		{"e"},
+	})
+
+	it := &PageIterator{ItemsField: "items"}
+
+	var got []interface{}
+	if err := it.ForEachPage(2, func(batch []interface{}) error {
+		got = append(got, batch...)
+		return nil
+	}); err != nil {
+		t.Fatalf("ForEachPage returned error: %v", err)
+	}
+
+	want := []interface{}{"a", "b", "c", "d", "e"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestPageIteratorForEachPage_emptyFirstPage(t *testing.T) {
+	withStubbedSendRequest(t, [][]interface{}{
+		{},
+		{"a"},
+	})
+
+	it := &PageIterator{ItemsField: "items"}
+
+	var got []interface{}
+	if err := it.ForEachPage(10, func(batch []interface{}) error {
+		got = append(got, batch...)
+		return nil
+	}); err != nil {
+		t.Fatalf("ForEachPage returned error: %v", err)
+	}
+
+	want := []interface{}{"a"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestPageIteratorForEachPage_shortFinalBatch(t *testing.T) {
+	withStubbedSendRequest(t, [][]interface{}{
+		{"a", "b", "c"},
+	})
+
+	it := &PageIterator{ItemsField: "items"}
+
+	var batches [][]interface{}
+	if err := it.ForEachPage(2, func(batch []interface{}) error {
+		// Copy since ForEachPage reuses/truncates its internal slice.
+		b := append([]interface{}{}, batch...)
+		batches = append(batches, b)
+		return nil
+	}); err != nil {
+		t.Fatalf("ForEachPage returned error: %v", err)
+	}
+
+	want := [][]interface{}{{"a", "b"}, {"c"}}
+	if !reflect.DeepEqual(batches, want) {
+		t.Errorf("got %v, want %v", batches, want)
+	}
+}
+
+func TestPageIteratorNext_doneAfterLastItem(t *testing.T) {
+	withStubbedSendRequest(t, [][]interface{}{
+		{"only"},
+	})
+
+	it := &PageIterator{ItemsField: "items"}
+
+	item, err := it.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item != "only" {
+		t.Errorf("got %v, want %q", item, "only")
+	}
+
+	if _, err := it.Next(); err != iterator.Done {
+		t.Errorf("got err=%v, want iterator.Done", err)
+	}
+}
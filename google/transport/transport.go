@@ -32,60 +32,100 @@ type SendRequestOptions struct {
 	ErrorRetryPredicates        []RetryErrorPredicateFunc
 	ErrorAbortPredicates        []RetryErrorPredicateFunc
 	ErrorRetryBackoffPredicates []RetryErrorPredicateFunc
+	// BackoffPolicy overrides DefaultBackoffPolicy for the backoff applied
+	// between ErrorRetryBackoffPredicates retries. Nil uses the default.
+	BackoffPolicy *BackoffPolicy
 }
 
-func wrapErrorRetryBackoffPredicates(fs []RetryErrorPredicateFunc) []RetryErrorPredicateFunc {
+// BackoffPolicy configures the full-jitter exponential backoff applied
+// between retries of ErrorRetryBackoffPredicates. It mirrors the knobs
+// gax-go exposes for the per-method retryers used by the google-cloud-go
+// KMS client, so callers that are rate-limited (KMS in particular) can
+// override the defaults.
+type BackoffPolicy struct {
+	// BaseDelay is the delay used to compute the backoff ceiling for the
+	// first retry attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff ceiling regardless of attempt count.
+	MaxDelay time.Duration
+	// Multiplier is applied to BaseDelay for each subsequent attempt, e.g.
+	// a Multiplier of 2 doubles the ceiling every attempt.
+	Multiplier float64
+	// MaxAttempts bounds the number of backoff-driven retries. 0 means
+	// unlimited.
+	MaxAttempts int
+}
+
+// DefaultBackoffPolicy is used whenever a SendRequestOptions does not
+// supply its own BackoffPolicy.
+var DefaultBackoffPolicy = BackoffPolicy{
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    60 * time.Second,
+	Multiplier:  2.0,
+	MaxAttempts: 0,
+}
+
+// backoffSleep is a package-level hook so tests can stub out the actual
+// sleep and assert on the computed delays.
+var backoffSleep = time.Sleep
+
+func wrapErrorRetryBackoffPredicates(fs []RetryErrorPredicateFunc, policy *BackoffPolicy) []RetryErrorPredicateFunc {
 	if fs == nil {
 		return fs
 	}
-	wrappedFuncs := make([]RetryErrorPredicateFunc, len(fs))
+	if policy == nil {
+		p := DefaultBackoffPolicy
+		policy = &p
+	}
+
+	wrappedFuncs := make([]RetryErrorPredicateFunc, 0, len(fs))
 	for _, f := range fs {
 
 		// Each function is wrapped with a closure with its own backoff struct
 		funcToWrap := f
 		backoff := struct {
-			attempts       int64
-			lastSleep      int64
-			minimumBackoff time.Duration
-			maximumBackoff time.Duration
-		}{
-			minimumBackoff: time.Duration(200),      // 200 ns
-			maximumBackoff: time.Duration(60 * 1e9), // 60 seconds
-		}
+			attempts int
+		}{}
 
 		var wf RetryErrorPredicateFunc = func(err error) (bool, string) {
 			// Reuse backoff struct via closure
 			b := &backoff
 
 			isRetryable, msg := funcToWrap(err)
-			if isRetryable {
-				log.Printf("[DEBUG] Retryable error with backoff starting")
-
-				// Sleep for period based on number of attempts so far
-				// https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/
-				// sleep = random_between(0, min(upperBound, base * 2 ** attempt))
-				lowerBound := b.minimumBackoff
-				upperBound := int64(math.Min(float64(b.maximumBackoff.Nanoseconds()), float64(b.lastSleep*int64(2)^b.attempts)))
-
-				r := rand.New(rand.NewSource(time.Now().UnixNano()))
-				sleep := r.Int63n((upperBound - lowerBound.Nanoseconds() + 1) + lowerBound.Nanoseconds())
-
-				time.Sleep(time.Duration(sleep))
-				switch {
-				case time.Duration(sleep).Seconds() >= 1:
-					log.Printf("[DEBUG] Slept for %s second(s)", time.Duration(sleep).Seconds())
-				case time.Duration(sleep).Milliseconds() >= 1:
-					log.Printf("[DEBUG] Slept for %s milliseconds(s)", time.Duration(sleep).Milliseconds())
-				case time.Duration(sleep).Microseconds() >= 1:
-					log.Printf("[DEBUG] Slept for %s microseconds(s)", time.Duration(sleep).Microseconds())
-				default:
-					log.Printf("[DEBUG] Slept for %s nanosecond(s)", time.Duration(sleep).Nanoseconds())
-				}
+			if !isRetryable {
+				return isRetryable, msg
+			}
 
-				// Update backoff struct for next time
-				b.attempts += 1
-				b.lastSleep = sleep
+			if policy.MaxAttempts > 0 && b.attempts >= policy.MaxAttempts {
+				return false, fmt.Sprintf("%s (backoff attempts exhausted after %d retries)", msg, b.attempts)
 			}
+
+			log.Printf("[DEBUG] Retryable error with backoff starting")
+
+			// Full jitter decorrelated backoff:
+			// sleep = random_between(0, min(cap, base * multiplier ** attempt))
+			// https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/
+			ceiling := math.Min(
+				float64(policy.MaxDelay),
+				float64(policy.BaseDelay)*math.Pow(policy.Multiplier, float64(b.attempts)),
+			)
+
+			r := rand.New(rand.NewSource(time.Now().UnixNano()))
+			sleep := time.Duration(r.Int63n(int64(ceiling) + 1))
+
+			backoffSleep(sleep)
+			switch {
+			case sleep.Seconds() >= 1:
+				log.Printf("[DEBUG] Slept for %s second(s)", sleep)
+			case sleep.Milliseconds() >= 1:
+				log.Printf("[DEBUG] Slept for %s millisecond(s)", sleep)
+			default:
+				log.Printf("[DEBUG] Slept for %s microsecond(s)", sleep)
+			}
+
+			// Update backoff struct for next time
+			b.attempts++
+
 			return isRetryable, msg
 		}
 		wrappedFuncs = append(wrappedFuncs, wf)
@@ -153,7 +193,7 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 		Timeout:                     opt.Timeout,
 		ErrorRetryPredicates:        opt.ErrorRetryPredicates,
 		ErrorAbortPredicates:        opt.ErrorAbortPredicates,
-		ErrorRetryBackoffPredicates: opt.ErrorRetryBackoffPredicates,
+		ErrorRetryBackoffPredicates: wrapErrorRetryBackoffPredicates(opt.ErrorRetryBackoffPredicates, opt.BackoffPolicy),
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+package transport
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWrapErrorRetryBackoffPredicates(t *testing.T) {
+	oldSleep := backoffSleep
+	defer func() { backoffSleep = oldSleep }()
+
+	var delays []time.Duration
+	backoffSleep = func(d time.Duration) {
+		delays = append(delays, d)
+	}
+
+	alwaysRetryable := func(err error) (bool, string) {
+		return true, "retryable"
+	}
+
+	policy := &BackoffPolicy{
+		BaseDelay:   10 * time.Millisecond,
+		MaxDelay:    80 * time.Millisecond,
+		Multiplier:  2.0,
+		MaxAttempts: 3,
+	}
+
+	wrapped := wrapErrorRetryBackoffPredicates([]RetryErrorPredicateFunc{alwaysRetryable}, policy)
+	if len(wrapped) != 1 {
+		t.Fatalf("expected 1 wrapped predicate, got %d", len(wrapped))
+	}
+
+	wantCeilings := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond}
+	for i, ceiling := range wantCeilings {
+		isRetryable, _ := wrapped[0](errors.New("boom"))
+		if !isRetryable {
+			t.Fatalf("attempt %d: expected retryable, got not retryable", i)
+		}
+		if delays[i] > ceiling {
+			t.Errorf("attempt %d: sleep %s exceeded expected ceiling %s", i, delays[i], ceiling)
+		}
+	}
+
+	// MaxAttempts is exhausted after 3 retries, so a 4th call should stop retrying.
+	isRetryable, msg := wrapped[0](errors.New("boom"))
+	if isRetryable {
+		t.Fatalf("expected retry budget to be exhausted, got retryable with message %q", msg)
+	}
+}